@@ -0,0 +1,105 @@
+// Package admin exposes the crawler's Prometheus metrics, pprof profiles,
+// a health probe and a small JSON query API over HTTP.
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/withoutaname/fund/sink"
+)
+
+// fundCodeRE matches eastmoney fund codes (digit strings, e.g. "000001").
+// Requests with a code outside this shape are rejected before they ever
+// reach the sink, let alone an InfluxQL query.
+var fundCodeRE = regexp.MustCompile(`^[0-9]{1,10}$`)
+
+// NewServer builds the admin HTTP handler. reader may be nil, in which
+// case the /api/funds endpoints respond with 501 Not Implemented; this is
+// the case for sinks (csv, parquet, prometheus) that cannot be queried back.
+func NewServer(reader sink.Reader) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("/metrics", promhttp.Handler())
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	mux.HandleFunc("/api/funds", handleListFunds(reader))
+	mux.HandleFunc("/api/funds/", handleNAV(reader))
+
+	return mux
+}
+
+func handleListFunds(reader sink.Reader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if reader == nil {
+			http.Error(w, "sink does not support queries", http.StatusNotImplemented)
+			return
+		}
+		nodes, err := reader.ListFunds()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, nodes)
+	}
+}
+
+func handleNAV(reader sink.Reader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if reader == nil {
+			http.Error(w, "sink does not support queries", http.StatusNotImplemented)
+			return
+		}
+		code := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/funds/"), "/nav")
+		if code == "" || !strings.HasSuffix(r.URL.Path, "/nav") {
+			http.NotFound(w, r)
+			return
+		}
+		if !fundCodeRE.MatchString(code) {
+			http.Error(w, "invalid fund code", http.StatusBadRequest)
+			return
+		}
+		from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+		if from != "" {
+			if _, err := time.Parse(time.RFC3339, from); err != nil {
+				http.Error(w, "invalid from timestamp, must be RFC3339", http.StatusBadRequest)
+				return
+			}
+		}
+		if to != "" {
+			if _, err := time.Parse(time.RFC3339, to); err != nil {
+				http.Error(w, "invalid to timestamp, must be RFC3339", http.StatusBadRequest)
+				return
+			}
+		}
+		details, err := reader.NAV(code, from, to)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, details)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/withoutaname/fund/fundsource"
+)
+
+type fakeReader struct {
+	nodes   []fundsource.FundNode
+	details []fundsource.FundDetail
+	err     error
+}
+
+func (f *fakeReader) ListFunds() ([]fundsource.FundNode, error) {
+	return f.nodes, f.err
+}
+
+func (f *fakeReader) NAV(code, from, to string) ([]fundsource.FundDetail, error) {
+	return f.details, f.err
+}
+
+func TestHealthz(t *testing.T) {
+	srv := httptest.NewServer(NewServer(nil))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestHandleListFundsNilReader(t *testing.T) {
+	srv := httptest.NewServer(NewServer(nil))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api/funds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotImplemented {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotImplemented)
+	}
+}
+
+func TestHandleListFunds(t *testing.T) {
+	reader := &fakeReader{nodes: []fundsource.FundNode{{Code: "000001", Name: "test fund"}}}
+	srv := httptest.NewServer(NewServer(reader))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api/funds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var nodes []fundsource.FundNode
+	if err := json.NewDecoder(resp.Body).Decode(&nodes); err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].Code != "000001" {
+		t.Errorf("nodes = %+v, want one node with code 000001", nodes)
+	}
+}
+
+func TestHandleNAV(t *testing.T) {
+	cases := []struct {
+		name       string
+		reader     *fakeReader
+		path       string
+		query      string
+		wantStatus int
+	}{
+		{"nil reader", nil, "/api/funds/000001/nav", "", http.StatusNotImplemented},
+		{"bad code", &fakeReader{}, "/api/funds/not-a-code/nav", "", http.StatusBadRequest},
+		{"bad from", &fakeReader{}, "/api/funds/000001/nav", "from=not-a-time", http.StatusBadRequest},
+		{"bad to", &fakeReader{}, "/api/funds/000001/nav", "to=not-a-time", http.StatusBadRequest},
+		{"not the nav path", &fakeReader{}, "/api/funds/000001", "", http.StatusNotFound},
+		{"ok", &fakeReader{details: []fundsource.FundDetail{{FSRQ: "2020-01-02", DWJZ: "1.2345"}}}, "/api/funds/000001/nav", "from=2020-01-01T00:00:00Z", http.StatusOK},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var reader *fakeReader
+			if c.reader != nil {
+				reader = c.reader
+			}
+			var handler http.Handler
+			if reader == nil {
+				handler = NewServer(nil)
+			} else {
+				handler = NewServer(reader)
+			}
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+
+			url := srv.URL + c.path
+			if c.query != "" {
+				url += "?" + c.query
+			}
+			resp, err := http.Get(url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != c.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, c.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Command fund crawls fund NAV history from eastmoney and writes it to a
+// configurable sink.
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/withoutaname/fund/admin"
+	"github.com/withoutaname/fund/crawler"
+	"github.com/withoutaname/fund/fundsource"
+	"github.com/withoutaname/fund/httpx"
+	"github.com/withoutaname/fund/sink"
+)
+
+var (
+	logger *zap.Logger
+
+	sinkType = flag.String("sink", "influxdb", "data sink to write fund data to: influxdb, prometheus, csv, parquet")
+
+	influxAddr = flag.String("influxdb-addr", "http://localhost:8086", "InfluxDB HTTP address")
+	influxDB   = flag.String("influxdb-database", "fund", "InfluxDB database name")
+
+	pushgatewayAddr = flag.String("pushgateway-addr", "http://localhost:9091", "Prometheus pushgateway address")
+	pushgatewayJob  = flag.String("pushgateway-job", "fund_crawler", "Prometheus pushgateway job name")
+
+	outputDir = flag.String("output-dir", "./data", "output directory for the csv and parquet sinks")
+
+	concurrency    = flag.Int("concurrency", 8, "number of funds to crawl concurrently")
+	checkpointPath = flag.String("checkpoint", "./fund_crawler.db", "path to the resume checkpoint database")
+
+	listenAddr = flag.String("listen", "", "address to serve metrics, pprof and the query API on, e.g. :8080 (disabled if empty)")
+
+	sourceHoldings  = flag.Bool("source-holdings", false, "also collect fund holdings (F10/FundArchivesDatas.aspx, type=jjcc)")
+	sourceDividends = flag.Bool("source-dividends", false, "also collect fund dividend history (fhsp)")
+	sourceManager   = flag.Bool("source-manager", false, "also collect fund manager info (jjjl)")
+	sourceEstimate  = flag.Bool("source-estimate", false, "also collect the intraday NAV estimate (fundgz)")
+)
+
+func enabledSources() []fundsource.Source {
+	var sources []fundsource.Source
+	if *sourceHoldings {
+		sources = append(sources, fundsource.HoldingsSource{})
+	}
+	if *sourceDividends {
+		sources = append(sources, fundsource.DividendsSource{})
+	}
+	if *sourceManager {
+		sources = append(sources, fundsource.ManagerSource{})
+	}
+	if *sourceEstimate {
+		sources = append(sources, fundsource.EstimateSource{})
+	}
+	return sources
+}
+
+func init() {
+	logger, _ = zap.NewDevelopment()
+}
+
+func newSink() (sink.Sink, error) {
+	switch *sinkType {
+	case "influxdb":
+		return sink.NewInfluxDBSink(sink.InfluxDBConfig{
+			Addr:     *influxAddr,
+			Database: *influxDB,
+		}, logger)
+	case "prometheus":
+		return sink.NewPrometheusSink(sink.PrometheusConfig{
+			PushGatewayAddr: *pushgatewayAddr,
+			Job:             *pushgatewayJob,
+		}), nil
+	case "csv":
+		return sink.NewCSVSink(sink.CSVConfig{Dir: *outputDir})
+	case "parquet":
+		return sink.NewParquetSink(sink.ParquetConfig{Dir: *outputDir}), nil
+	default:
+		return nil, &unknownSinkError{*sinkType}
+	}
+}
+
+type unknownSinkError struct {
+	sink string
+}
+
+func (e *unknownSinkError) Error() string {
+	return "unknown sink type: " + e.sink
+}
+
+func run(ctx context.Context, pool *crawler.Pool) error {
+	nodeList, err := fundsource.GetNodeList(ctx, pool.Client)
+	if err != nil {
+		return err
+	}
+	return pool.Run(ctx, nodeList)
+}
+
+func main() {
+	flag.Parse()
+
+	s, err := newSink()
+	if err != nil {
+		logger.Fatal("init sink error", zap.Error(err))
+	}
+
+	checkpoint, err := crawler.OpenCheckpoint(*checkpointPath)
+	if err != nil {
+		logger.Fatal("init checkpoint error", zap.Error(err))
+	}
+	defer checkpoint.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *listenAddr != "" {
+		reader, _ := s.(sink.Reader)
+		server := &http.Server{Addr: *listenAddr, Handler: admin.NewServer(reader)}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("admin server error", zap.Error(err))
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			server.Close()
+		}()
+		logger.Info("admin server listening", zap.String("addr", *listenAddr))
+	}
+
+	pool := &crawler.Pool{
+		Client:      httpx.New(httpx.DefaultConfig, fundsource.Headers),
+		Sink:        s,
+		Sources:     enabledSources(),
+		Checkpoint:  checkpoint,
+		Concurrency: *concurrency,
+		Logger:      logger,
+	}
+
+	for ctx.Err() == nil {
+		logger.Info("begin run", zap.String("sink", *sinkType), zap.Int("concurrency", *concurrency))
+		err := run(ctx, pool)
+		if err != nil && ctx.Err() == nil {
+			logger.Error("run error", zap.Error(err))
+		}
+		// A completed pass starts the next one from a clean slate; a crash
+		// or cancellation mid-pass leaves the checkpoint in place so the
+		// next process picks up where this one left off.
+		if err == nil {
+			if err := checkpoint.Reset(); err != nil {
+				logger.Error("checkpoint reset error", zap.Error(err))
+			}
+		}
+	}
+	logger.Info("shutting down", zap.Error(ctx.Err()))
+}
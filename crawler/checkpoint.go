@@ -0,0 +1,65 @@
+package crawler
+
+import (
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var doneBucket = []byte("done")
+
+// Checkpoint persists which fund codes have already been crawled during
+// the current run window, so a restart does not redo completed work.
+type Checkpoint struct {
+	db *bbolt.DB
+}
+
+// OpenCheckpoint opens (creating if necessary) a checkpoint database at path.
+func OpenCheckpoint(path string) (*Checkpoint, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(doneBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Checkpoint{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (c *Checkpoint) Close() error {
+	return c.db.Close()
+}
+
+// IsDone reports whether code was already marked done in this run window.
+func (c *Checkpoint) IsDone(code string) bool {
+	var done bool
+	c.db.View(func(tx *bbolt.Tx) error {
+		done = tx.Bucket(doneBucket).Get([]byte(code)) != nil
+		return nil
+	})
+	return done
+}
+
+// MarkDone records that code has been crawled.
+func (c *Checkpoint) MarkDone(code string) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(doneBucket).Put([]byte(code), []byte(time.Now().UTC().Format(time.RFC3339)))
+	})
+}
+
+// Reset clears all recorded progress, starting a new run window.
+func (c *Checkpoint) Reset() error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket(doneBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(doneBucket)
+		return err
+	})
+}
@@ -0,0 +1,73 @@
+package crawler
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointMarkDoneAndIsDone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.db")
+	cp, err := OpenCheckpoint(path)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint: %v", err)
+	}
+	defer cp.Close()
+
+	if cp.IsDone("000001") {
+		t.Fatal("fund should not be done before MarkDone")
+	}
+	if err := cp.MarkDone("000001"); err != nil {
+		t.Fatalf("MarkDone: %v", err)
+	}
+	if !cp.IsDone("000001") {
+		t.Fatal("fund should be done after MarkDone")
+	}
+	if cp.IsDone("000002") {
+		t.Fatal("an unrelated fund should not be marked done")
+	}
+}
+
+func TestCheckpointPersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.db")
+	cp, err := OpenCheckpoint(path)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint: %v", err)
+	}
+	if err := cp.MarkDone("000001"); err != nil {
+		t.Fatalf("MarkDone: %v", err)
+	}
+	if err := cp.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	cp2, err := OpenCheckpoint(path)
+	if err != nil {
+		t.Fatalf("reopen OpenCheckpoint: %v", err)
+	}
+	defer cp2.Close()
+	if !cp2.IsDone("000001") {
+		t.Fatal("checkpoint should survive close/reopen")
+	}
+}
+
+func TestCheckpointReset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.db")
+	cp, err := OpenCheckpoint(path)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint: %v", err)
+	}
+	defer cp.Close()
+
+	if err := cp.MarkDone("000001"); err != nil {
+		t.Fatalf("MarkDone: %v", err)
+	}
+	if err := cp.Reset(); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if cp.IsDone("000001") {
+		t.Fatal("fund should no longer be done after Reset")
+	}
+	if err := cp.MarkDone("000001"); err != nil {
+		t.Fatalf("MarkDone after Reset: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+// Package crawler drives concurrent, incremental crawling of fund NAV
+// history with a bounded worker pool and a resume checkpoint.
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/withoutaname/fund/fundsource"
+	"github.com/withoutaname/fund/httpx"
+	"github.com/withoutaname/fund/metrics"
+	"github.com/withoutaname/fund/sink"
+)
+
+// Pool crawls a list of fund nodes with bounded concurrency, resuming from
+// each fund's last known NAV date when the sink supports it.
+type Pool struct {
+	Client      *httpx.Client
+	Sink        sink.Sink
+	Sources     []fundsource.Source
+	Checkpoint  *Checkpoint
+	Concurrency int
+	Logger      *zap.Logger
+}
+
+// Run crawls nodeList, fanning out across p.Concurrency workers. It blocks
+// until every node has been attempted or ctx is canceled.
+func (p *Pool) Run(ctx context.Context, nodeList []fundsource.FundNode) error {
+	concurrency := p.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	if len(p.Sources) > 0 {
+		if _, ok := p.Sink.(sink.RecordWriter); !ok {
+			p.Logger.Warn("sink does not support additional sources, they will be skipped", zap.Int("sources", len(p.Sources)))
+		}
+	}
+
+	jobs := make(chan fundsource.FundNode)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for node := range jobs {
+				p.crawlOne(ctx, node)
+			}
+		}()
+	}
+
+	metrics.QueueDepth.Set(float64(len(nodeList)))
+feed:
+	for _, node := range nodeList {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- node:
+			metrics.QueueDepth.Dec()
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (p *Pool) crawlOne(ctx context.Context, node fundsource.FundNode) {
+	if p.Checkpoint != nil && p.Checkpoint.IsDone(node.Code) {
+		return
+	}
+
+	startDate := ""
+	if provider, ok := p.Sink.(sink.LatestDateProvider); ok {
+		if latest, err := provider.LatestDate(node.Code); err != nil {
+			p.Logger.Error("latest date lookup error", zap.String("code", node.Code), zap.Error(err))
+		} else {
+			startDate = fundsource.FormatStartDate(latest)
+		}
+	}
+
+	points, err := fundsource.GetFundSince(ctx, p.Client, node, startDate)
+	if err != nil {
+		p.Logger.Error("get fund error", zap.String("code", node.Code), zap.String("name", node.Name), zap.Error(err))
+		return
+	}
+	start := time.Now()
+	err = p.Sink.Write(node, points)
+	metrics.ObserveSinkWrite(fmt.Sprintf("%T", p.Sink), start)
+	if err != nil {
+		p.Logger.Error("sink error", zap.String("code", node.Code), zap.String("name", node.Name), zap.Error(err))
+		return
+	}
+	metrics.FundsScraped.Inc()
+
+	p.collectSources(ctx, node)
+
+	if p.Checkpoint != nil {
+		if err := p.Checkpoint.MarkDone(node.Code); err != nil {
+			p.Logger.Error("checkpoint error", zap.String("code", node.Code), zap.Error(err))
+		}
+	}
+}
+
+func (p *Pool) collectSources(ctx context.Context, node fundsource.FundNode) {
+	writer, ok := p.Sink.(sink.RecordWriter)
+	if !ok {
+		return
+	}
+	for _, src := range p.Sources {
+		records, err := src.Collect(ctx, p.Client, node)
+		if err != nil {
+			p.Logger.Error("collect source error", zap.String("code", node.Code), zap.String("measurement", src.Measurement()), zap.Error(err))
+			continue
+		}
+		if err := writer.WriteRecords(src.Measurement(), records); err != nil {
+			p.Logger.Error("write records error", zap.String("code", node.Code), zap.String("measurement", src.Measurement()), zap.Error(err))
+		}
+	}
+}
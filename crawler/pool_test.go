@@ -0,0 +1,91 @@
+package crawler
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/withoutaname/fund/fundsource"
+)
+
+type fakeSink struct {
+	mu     sync.Mutex
+	writes int
+}
+
+func (f *fakeSink) Write(node fundsource.FundNode, points []fundsource.FundDetail) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writes++
+	return nil
+}
+
+// TestPoolRunSkipsCheckpointedNodes exercises the worker pool's fan-out
+// without any network access: every node is pre-marked done, so crawlOne
+// must return via the checkpoint short-circuit before ever touching
+// p.Client or p.Sink.
+func TestPoolRunSkipsCheckpointedNodes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.db")
+	cp, err := OpenCheckpoint(path)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint: %v", err)
+	}
+	defer cp.Close()
+
+	nodes := []fundsource.FundNode{{Code: "000001"}, {Code: "000002"}, {Code: "000003"}}
+	for _, n := range nodes {
+		if err := cp.MarkDone(n.Code); err != nil {
+			t.Fatalf("MarkDone(%s): %v", n.Code, err)
+		}
+	}
+
+	sink := &fakeSink{}
+	p := &Pool{
+		Sink:        sink,
+		Checkpoint:  cp,
+		Concurrency: 4,
+		Logger:      zap.NewNop(),
+	}
+
+	if err := p.Run(context.Background(), nodes); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if sink.writes != 0 {
+		t.Fatalf("got %d sink writes, want 0 (all nodes were already checkpointed)", sink.writes)
+	}
+}
+
+// TestPoolRunRespectsContextCancellation verifies Run stops feeding jobs
+// and returns once ctx is canceled, rather than blocking forever.
+func TestPoolRunRespectsContextCancellation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.db")
+	cp, err := OpenCheckpoint(path)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint: %v", err)
+	}
+	defer cp.Close()
+
+	nodes := make([]fundsource.FundNode, 100)
+	for i := range nodes {
+		nodes[i] = fundsource.FundNode{Code: "000001"}
+	}
+	for _, n := range nodes {
+		cp.MarkDone(n.Code)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := &Pool{
+		Sink:        &fakeSink{},
+		Checkpoint:  cp,
+		Concurrency: 2,
+		Logger:      zap.NewNop(),
+	}
+	if err := p.Run(ctx, nodes); err == nil {
+		t.Fatal("expected Run to return ctx.Err() after cancellation")
+	}
+}
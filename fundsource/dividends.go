@@ -0,0 +1,88 @@
+package fundsource
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+
+	"github.com/withoutaname/fund/httpx"
+)
+
+// DividendInfo is the decoded fhsp (分红送配) API response, shaped like
+// the lsjz response it sits alongside.
+type DividendInfo struct {
+	Data struct {
+		FHSPList []struct {
+			// 权益登记日
+			QEJZR string
+			// 每份分红
+			FHFCZ string
+			// 分红发放日
+			DVDATE string
+		}
+	}
+	ErrCode int
+	ErrMsg  string
+}
+
+// DividendsSource collects a fund's dividend ("分红送配", fhsp) history.
+type DividendsSource struct{}
+
+func (DividendsSource) Measurement() string { return "fund_dividend" }
+
+func (s DividendsSource) Collect(ctx context.Context, client *httpx.Client, node FundNode) ([]Record, error) {
+	if node.Code == "" {
+		return nil, fmt.Errorf("empty fund code")
+	}
+	timestamp := (time.Now().Unix()-2)*1000 - rand.Int63n(1000)
+	u := fmt.Sprintf("http://api.fund.eastmoney.com/f10/fhsp?callback=jQuer&fundCode=%s&pageIndex=1&pageSize=20&_=%d", node.Code, timestamp)
+	resp, err := client.Get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var info DividendInfo
+	if err := decodeJSONP(resp, &info); err != nil {
+		return nil, err
+	}
+	if info.ErrCode != 0 {
+		return nil, fmt.Errorf("dividend info error, code=[%d], msg=[%s]", info.ErrCode, info.ErrMsg)
+	}
+
+	return parseDividendInfo(info, node.Code), nil
+}
+
+// parseDividendInfo builds one Record per dividend event, split out of
+// Collect so it can be exercised directly in tests. A record with an
+// unparseable FHFCZ is still emitted (the event itself is real), just
+// without a per_share field; sink.WriteRecords is responsible for
+// dropping records whose Fields end up empty.
+func parseDividendInfo(info DividendInfo, code string) []Record {
+	var records []Record
+	for _, d := range info.Data.FHSPList {
+		if d.QEJZR == "" {
+			continue
+		}
+		t, err := dateparse.ParseLocal(d.QEJZR)
+		if err != nil {
+			continue
+		}
+		fields := map[string]interface{}{}
+		if fhfcz, err := strconv.ParseFloat(strings.TrimSpace(d.FHFCZ), 64); err == nil {
+			fields["per_share"] = fhfcz
+		}
+		records = append(records, Record{
+			Time: t,
+			Tags: map[string]string{
+				"code": code,
+			},
+			Fields: fields,
+		})
+	}
+	return records
+}
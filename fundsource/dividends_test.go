@@ -0,0 +1,45 @@
+package fundsource
+
+import "testing"
+
+// TestParseDividendInfoEmptyFHFCZYieldsEmptyFields is a regression test for
+// the batch-abort bug fixed in sink.WriteRecords: a dividend event with an
+// unparseable FHFCZ must still produce a Record (the event itself is real),
+// just with an empty Fields map.
+func TestParseDividendInfoEmptyFHFCZYieldsEmptyFields(t *testing.T) {
+	info := DividendInfo{}
+	info.Data.FHSPList = []struct {
+		QEJZR  string
+		FHFCZ  string
+		DVDATE string
+	}{
+		{QEJZR: "2024-01-01", FHFCZ: ""},
+		{QEJZR: "2024-06-01", FHFCZ: "0.15"},
+	}
+
+	records := parseDividendInfo(info, "000001")
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if len(records[0].Fields) != 0 {
+		t.Errorf("records[0].Fields = %v, want empty for a blank FHFCZ", records[0].Fields)
+	}
+	if got, ok := records[1].Fields["per_share"]; !ok || got != 0.15 {
+		t.Errorf("records[1].Fields[per_share] = %v, want 0.15", got)
+	}
+}
+
+func TestParseDividendInfoSkipsUnparseableDate(t *testing.T) {
+	info := DividendInfo{}
+	info.Data.FHSPList = []struct {
+		QEJZR  string
+		FHFCZ  string
+		DVDATE string
+	}{
+		{QEJZR: "", FHFCZ: "0.1"},
+		{QEJZR: "not-a-date", FHFCZ: "0.1"},
+	}
+	if records := parseDividendInfo(info, "000001"); len(records) != 0 {
+		t.Fatalf("got %d records, want 0 for events with no usable date", len(records))
+	}
+}
@@ -0,0 +1,80 @@
+package fundsource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+
+	"github.com/withoutaname/fund/httpx"
+)
+
+// estimateInfo is the decoded jsonpgz(...) payload from the intraday
+// NAV estimate endpoint.
+type estimateInfo struct {
+	FundCode string `json:"fundcode"`
+	Name     string `json:"name"`
+	// 最近净值日期
+	JZRQ string `json:"jzrq"`
+	// 最近单位净值
+	DWJZ string `json:"dwjz"`
+	// 估算净值
+	GSZ string `json:"gsz"`
+	// 估算涨跌幅
+	GSZZL string `json:"gszzl"`
+	// 估值时间
+	GZTime string `json:"gztime"`
+}
+
+// EstimateSource collects the intraday NAV estimate eastmoney publishes
+// during trading hours, well before the official NAV is disclosed.
+type EstimateSource struct{}
+
+func (EstimateSource) Measurement() string { return "fund_estimate" }
+
+func (s EstimateSource) Collect(ctx context.Context, client *httpx.Client, node FundNode) ([]Record, error) {
+	if node.Code == "" {
+		return nil, fmt.Errorf("empty fund code")
+	}
+	u := fmt.Sprintf("http://fundgz.1234567.com.cn/js/%s.js", node.Code)
+	resp, err := client.Get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var info estimateInfo
+	if err := decodeJSONP(resp, &info); err != nil {
+		return nil, err
+	}
+
+	return []Record{parseEstimateInfo(info, node.Code)}, nil
+}
+
+// parseEstimateInfo builds the Record for a single intraday estimate, split
+// out of Collect so it can be exercised directly in tests. Outside trading
+// hours, or for funds with no live estimate, GSZ/GSZZL can both be blank;
+// the returned Record's Fields may then be empty, which sink.WriteRecords
+// is responsible for filtering out before writing.
+func parseEstimateInfo(info estimateInfo, code string) Record {
+	t, err := dateparse.ParseLocal(info.GZTime)
+	if err != nil {
+		t = time.Now()
+	}
+	fields := map[string]interface{}{}
+	if gsz, err := strconv.ParseFloat(info.GSZ, 64); err == nil {
+		fields["estimated_nav"] = gsz
+	}
+	if gszzl, err := strconv.ParseFloat(strings.TrimSpace(info.GSZZL), 64); err == nil {
+		fields["estimated_change_percent"] = gszzl
+	}
+	return Record{
+		Time: t,
+		Tags: map[string]string{
+			"code": code,
+		},
+		Fields: fields,
+	}
+}
@@ -0,0 +1,30 @@
+package fundsource
+
+import "testing"
+
+// TestParseEstimateInfoNoLiveEstimateYieldsEmptyFields is a regression test
+// for the batch-abort bug fixed in sink.WriteRecords: outside trading
+// hours, or for funds with no live estimate, both GSZ and GSZZL can be
+// blank, and parseEstimateInfo must still return a Record rather than
+// erroring, just with an empty Fields map.
+func TestParseEstimateInfoNoLiveEstimateYieldsEmptyFields(t *testing.T) {
+	info := estimateInfo{GSZ: "", GSZZL: ""}
+	r := parseEstimateInfo(info, "000001")
+	if len(r.Fields) != 0 {
+		t.Errorf("Fields = %v, want empty when GSZ/GSZZL are both blank", r.Fields)
+	}
+	if r.Tags["code"] != "000001" {
+		t.Errorf("code tag = %q, want 000001", r.Tags["code"])
+	}
+}
+
+func TestParseEstimateInfoParsesAvailableFields(t *testing.T) {
+	info := estimateInfo{GSZ: "1.2345", GSZZL: " 0.56"}
+	r := parseEstimateInfo(info, "000001")
+	if got := r.Fields["estimated_nav"]; got != 1.2345 {
+		t.Errorf("estimated_nav = %v, want 1.2345", got)
+	}
+	if got := r.Fields["estimated_change_percent"]; got != 0.56 {
+		t.Errorf("estimated_change_percent = %v, want 0.56", got)
+	}
+}
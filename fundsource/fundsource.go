@@ -0,0 +1,198 @@
+// Package fundsource fetches fund metadata and NAV history from eastmoney.
+package fundsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+
+	"github.com/withoutaname/fund/httpx"
+)
+
+// Headers are the default request headers used against eastmoney; the
+// Referer must point at a real jjjz page or requests are rejected.
+var Headers = http.Header{
+	"Referer":    []string{"http://fund.eastmoney.com/f10/jjjz_519961.html"},
+	"User-Agent": []string{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/74.0.3729.169 Safari/537.36"},
+}
+
+// FundNode identifies a single fund as listed in eastmoney's fund code index.
+type FundNode struct {
+	Code    string
+	Abridge string
+	Name    string
+	Type    string
+	Pinyin  string
+}
+
+// FundDetail is a single day's NAV record for a fund.
+type FundDetail struct {
+	// 日期
+	FSRQ string
+	// 单位净值
+	DWJZ string
+	// 累计净值
+	LJJZ string
+
+	SDATE     string
+	ACTUALSYI string
+	NAVTYPE   string
+	JZZZL     string
+	// 申购状态
+	SGZT string
+	// 赎回状态
+	SHZT   string
+	FHFCZ  string
+	FHFCBZ string
+	DTYPE  string
+	FHSP   string
+}
+
+type FundDetails struct {
+	LSJZList  []FundDetail
+	FundType  string
+	SYType    string
+	isNewType bool
+	Feature   string
+}
+
+// FundInfo is the decoded lsjz API response.
+type FundInfo struct {
+	Data       FundDetails
+	ErrCode    int
+	ErrMsg     string
+	TotalCount int
+	Expansion  string
+	PageSize   int
+	PageIndex  int
+}
+
+// GetNodeList fetches the full list of fund codes known to eastmoney.
+func GetNodeList(ctx context.Context, client *httpx.Client) ([]FundNode, error) {
+	resp, err := client.Get(ctx, "http://fund.eastmoney.com/js/fundcode_search.js")
+	if err != nil {
+		return nil, err
+	}
+	body := resp.Body
+	defer body.Close()
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(body)
+	s := buf.String()
+	s = s[strings.Index(s, "=")+1:]
+	s = strings.TrimSpace(s)
+	s = s[1 : len(s)-3]
+	lists := strings.Split(s, "],")
+	nodeList := make([]FundNode, len(lists))
+	for i, list := range lists {
+		segs := strings.Split(list, "\",\"")
+		if len(segs) != 5 {
+			log.Printf("invalid node: %s\n", list)
+			continue
+		}
+		nodeList[i] = FundNode{
+			Code:    strings.Trim(segs[0], "\"[]"),
+			Abridge: strings.Trim(segs[1], "\"[]"),
+			Name:    strings.Trim(segs[2], "\"[]"),
+			Type:    strings.Trim(segs[3], "\"[]"),
+			Pinyin:  strings.Trim(segs[4], "\"[]"),
+		}
+	}
+	return nodeList, nil
+}
+
+func parseFund(resp *http.Response) (FundInfo, error) {
+	var info FundInfo
+	err := decodeJSONP(resp, &info)
+	return info, err
+}
+
+// decodeJSONP decodes a JSONP response body of the form "foo({...})" into
+// v, used by eastmoney's f10 endpoints. It closes resp.Body.
+func decodeJSONP(resp *http.Response, v interface{}) error {
+	defer resp.Body.Close()
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	data := string(bs)
+	beg := strings.Index(data, "{")
+	if beg == -1 {
+		return fmt.Errorf("invalid response body")
+	}
+	data = data[beg:]
+	dec := json.NewDecoder(strings.NewReader(data))
+	return dec.Decode(v)
+}
+
+const lsjzPageSize = 20
+
+// GetFundPage fetches a single page of NAV history for node from the lsjz
+// API. startDate may be empty to fetch from the beginning of the fund's
+// history; otherwise it restricts the result to records on or after it.
+func GetFundPage(ctx context.Context, client *httpx.Client, node FundNode, startDate string, pageIndex int) (FundInfo, error) {
+	if node.Code == "" {
+		return FundInfo{}, fmt.Errorf("empty fund code")
+	}
+	timestamp := (time.Now().Unix()-2)*1000 - rand.Int63n(1000)
+	fundUrl := fmt.Sprintf("http://api.fund.eastmoney.com/f10/lsjz?callback=jQuer&fundCode=%s&pageIndex=%d&pageSize=%d&startDate=%s&endDate=&_=%d", node.Code, pageIndex, lsjzPageSize, startDate, timestamp)
+	resp, err := client.Get(ctx, fundUrl)
+	if err != nil {
+		return FundInfo{}, err
+	}
+	fundInfo, err := parseFund(resp)
+	if err != nil {
+		return FundInfo{}, err
+	}
+	if fundInfo.ErrCode != 0 {
+		return FundInfo{}, fmt.Errorf("fund info error, code=[%d], msg=[%s], index=[%d], size=[%d]", fundInfo.ErrCode, fundInfo.ErrMsg, fundInfo.PageIndex, fundInfo.PageSize)
+	}
+	return fundInfo, nil
+}
+
+// GetFund fetches the first page of NAV history for node from the lsjz API.
+func GetFund(ctx context.Context, client *httpx.Client, node FundNode) (FundInfo, error) {
+	return GetFundPage(ctx, client, node, "", 1)
+}
+
+// FormatStartDate reformats a timestamp (e.g. the RFC3339 string an
+// InfluxDB query returns) into the bare yyyy-MM-dd form the lsjz API's
+// startDate parameter expects, consistent with how FSRQ values look
+// elsewhere in this package. An empty or unparseable input returns "".
+func FormatStartDate(ts string) string {
+	if ts == "" {
+		return ""
+	}
+	t, err := dateparse.ParseLocal(ts)
+	if err != nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// GetFundSince fetches every NAV record for node on or after startDate,
+// paging through the lsjz API until TotalCount records have been collected.
+// startDate may be empty to fetch the fund's full history.
+func GetFundSince(ctx context.Context, client *httpx.Client, node FundNode, startDate string) ([]FundDetail, error) {
+	var all []FundDetail
+	pageIndex := 1
+	for {
+		info, err := GetFundPage(ctx, client, node, startDate, pageIndex)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, info.Data.LSJZList...)
+		if len(info.Data.LSJZList) == 0 || len(all) >= info.TotalCount {
+			return all, nil
+		}
+		pageIndex++
+	}
+}
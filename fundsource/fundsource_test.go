@@ -0,0 +1,76 @@
+package fundsource
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type closeTrackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestDecodeJSONPClosesBody(t *testing.T) {
+	body := &closeTrackingBody{Reader: strings.NewReader(`jQuer({"ErrCode":0,"ErrMsg":""})`)}
+	resp := &http.Response{Body: body}
+
+	var info FundInfo
+	if err := decodeJSONP(resp, &info); err != nil {
+		t.Fatalf("decodeJSONP: %v", err)
+	}
+	if !body.closed {
+		t.Fatal("decodeJSONP did not close resp.Body")
+	}
+}
+
+func TestDecodeJSONPParsesWrappedJSON(t *testing.T) {
+	body := &closeTrackingBody{Reader: strings.NewReader(`jQuer({"ErrCode":7,"ErrMsg":"boom"})`)}
+	resp := &http.Response{Body: body}
+
+	var info FundInfo
+	if err := decodeJSONP(resp, &info); err != nil {
+		t.Fatalf("decodeJSONP: %v", err)
+	}
+	if info.ErrCode != 7 || info.ErrMsg != "boom" {
+		t.Fatalf("got %+v, want ErrCode=7 ErrMsg=boom", info)
+	}
+}
+
+func TestDecodeJSONPRejectsBodyWithoutBrace(t *testing.T) {
+	body := &closeTrackingBody{Reader: strings.NewReader(`not json at all`)}
+	resp := &http.Response{Body: body}
+
+	var info FundInfo
+	if err := decodeJSONP(resp, &info); err == nil {
+		t.Fatal("expected an error for a body with no JSON object")
+	}
+	if !body.closed {
+		t.Fatal("decodeJSONP should close resp.Body even on error")
+	}
+}
+
+func TestFormatStartDate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "empty", in: "", want: ""},
+		{name: "unparseable", in: "not-a-date", want: ""},
+		{name: "rfc3339", in: "2024-03-05T00:00:00Z", want: "2024-03-05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatStartDate(tt.in); got != tt.want {
+				t.Errorf("FormatStartDate(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
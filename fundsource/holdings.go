@@ -0,0 +1,83 @@
+package fundsource
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/withoutaname/fund/httpx"
+)
+
+// HoldingsSource collects a fund's top stock holdings from eastmoney's
+// F10 archives page (type=jjcc).
+type HoldingsSource struct{}
+
+func (HoldingsSource) Measurement() string { return "fund_holding" }
+
+// (?s) lets "." match newlines: eastmoney's archives ASPX output is
+// pretty-printed, with rows and cells frequently spanning multiple lines.
+var holdingRowRE = regexp.MustCompile(`(?s)<tr>(.*?)</tr>`)
+var holdingCellRE = regexp.MustCompile(`(?s)<td[^>]*>(.*?)</td>`)
+var holdingPercentRE = regexp.MustCompile(`[\d.]+`)
+
+// Collect fetches the fund's latest disclosed holdings. The endpoint
+// returns a JSONP wrapper around an HTML table, so the table is scraped
+// with a small regexp rather than a full HTML parser.
+func (s HoldingsSource) Collect(ctx context.Context, client *httpx.Client, node FundNode) ([]Record, error) {
+	if node.Code == "" {
+		return nil, fmt.Errorf("empty fund code")
+	}
+	u := fmt.Sprintf("http://api.fund.eastmoney.com/F10/FundArchivesDatas.aspx?type=jjcc&code=%s&topline=10", node.Code)
+	resp, err := client.Get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseHoldingsHTML(string(bs), node.Code, time.Now()), nil
+}
+
+// parseHoldingsHTML scrapes the holdings table out of html, tagging each
+// row with code. Split out of Collect so the table-scraping regexes can be
+// exercised directly in tests.
+func parseHoldingsHTML(html string, code string, now time.Time) []Record {
+	var records []Record
+	for _, rowMatch := range holdingRowRE.FindAllStringSubmatch(html, -1) {
+		cells := holdingCellRE.FindAllStringSubmatch(rowMatch[1], -1)
+		if len(cells) < 7 {
+			continue
+		}
+		stockCode := strings.TrimSpace(stripTags(cells[1][1]))
+		stockName := strings.TrimSpace(stripTags(cells[2][1]))
+		if stockCode == "" {
+			continue
+		}
+		percent, _ := strconv.ParseFloat(holdingPercentRE.FindString(cells[6][1]), 64)
+		records = append(records, Record{
+			Time: now,
+			Tags: map[string]string{
+				"code":       code,
+				"stock_code": stockCode,
+				"stock_name": stockName,
+			},
+			Fields: map[string]interface{}{
+				"percent": percent,
+			},
+		})
+	}
+	return records
+}
+
+var tagRE = regexp.MustCompile(`<[^>]*>`)
+
+func stripTags(s string) string {
+	return tagRE.ReplaceAllString(s, "")
+}
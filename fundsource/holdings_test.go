@@ -0,0 +1,58 @@
+package fundsource
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseHoldingsHTMLMultilineRow is a regression test for 6a568db: the
+// archives ASPX output pretty-prints rows with cells spanning multiple
+// lines, which a non-(?s) regexp fails to match at all.
+func TestParseHoldingsHTMLMultilineRow(t *testing.T) {
+	html := `<table>
+<tr>
+  <td>1</td>
+  <td>
+    600519
+  </td>
+  <td>
+    贵州茅台
+  </td>
+  <td>4</td>
+  <td>5</td>
+  <td>6</td>
+  <td>
+    12.34%
+  </td>
+</tr>
+</table>`
+
+	now := time.Now()
+	records := parseHoldingsHTML(html, "000001", now)
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	r := records[0]
+	if r.Tags["stock_code"] != "600519" {
+		t.Errorf("stock_code = %q, want 600519", r.Tags["stock_code"])
+	}
+	if r.Tags["stock_name"] != "贵州茅台" {
+		t.Errorf("stock_name = %q, want 贵州茅台", r.Tags["stock_name"])
+	}
+	if r.Fields["percent"] != 12.34 {
+		t.Errorf("percent = %v, want 12.34", r.Fields["percent"])
+	}
+}
+
+func TestParseHoldingsHTMLSkipsShortRows(t *testing.T) {
+	html := `<tr><td>1</td><td>600519</td></tr>`
+	if records := parseHoldingsHTML(html, "000001", time.Now()); records != nil {
+		t.Fatalf("got %d records for a row with too few cells, want 0", len(records))
+	}
+}
+
+func TestStripTags(t *testing.T) {
+	if got := stripTags("<a href=\"x\">600519</a>"); got != "600519" {
+		t.Errorf("stripTags = %q, want 600519", got)
+	}
+}
@@ -0,0 +1,74 @@
+package fundsource
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/withoutaname/fund/httpx"
+)
+
+// ManagerInfo is the decoded jjjl (基金经理) API response.
+type ManagerInfo struct {
+	Data struct {
+		Data []struct {
+			Name      string
+			StartDate string
+			// 任职天数
+			WorkingDays int
+			// 任职回报
+			Return string
+		}
+	}
+	ErrCode int
+	ErrMsg  string
+}
+
+// ManagerSource collects a fund's current and past manager assignments.
+type ManagerSource struct{}
+
+func (ManagerSource) Measurement() string { return "fund_manager" }
+
+func (s ManagerSource) Collect(ctx context.Context, client *httpx.Client, node FundNode) ([]Record, error) {
+	if node.Code == "" {
+		return nil, fmt.Errorf("empty fund code")
+	}
+	timestamp := (time.Now().Unix()-2)*1000 - rand.Int63n(1000)
+	u := fmt.Sprintf("http://api.fund.eastmoney.com/f10/jjjl?callback=jQuer&fundCode=%s&_=%d", node.Code, timestamp)
+	resp, err := client.Get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var info ManagerInfo
+	if err := decodeJSONP(resp, &info); err != nil {
+		return nil, err
+	}
+	if info.ErrCode != 0 {
+		return nil, fmt.Errorf("manager info error, code=[%d], msg=[%s]", info.ErrCode, info.ErrMsg)
+	}
+
+	return parseManagerInfo(info, node.Code, time.Now()), nil
+}
+
+// parseManagerInfo builds one Record per manager assignment, split out of
+// Collect so it can be exercised directly in tests.
+func parseManagerInfo(info ManagerInfo, code string, now time.Time) []Record {
+	records := make([]Record, 0, len(info.Data.Data))
+	for _, m := range info.Data.Data {
+		records = append(records, Record{
+			Time: now,
+			Tags: map[string]string{
+				"code": code,
+				"name": m.Name,
+			},
+			Fields: map[string]interface{}{
+				"start_date":   m.StartDate,
+				"working_days": m.WorkingDays,
+				"return":       m.Return,
+			},
+		})
+	}
+	return records
+}
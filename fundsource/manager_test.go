@@ -0,0 +1,31 @@
+package fundsource
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseManagerInfo(t *testing.T) {
+	info := ManagerInfo{}
+	info.Data.Data = []struct {
+		Name        string
+		StartDate   string
+		WorkingDays int
+		Return      string
+	}{
+		{Name: "张三", StartDate: "2020-01-01", WorkingDays: 100, Return: "12.34%"},
+	}
+
+	now := time.Now()
+	records := parseManagerInfo(info, "000001", now)
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	r := records[0]
+	if r.Tags["code"] != "000001" || r.Tags["name"] != "张三" {
+		t.Errorf("tags = %+v, want code=000001 name=张三", r.Tags)
+	}
+	if r.Fields["start_date"] != "2020-01-01" || r.Fields["working_days"] != 100 || r.Fields["return"] != "12.34%" {
+		t.Errorf("fields = %+v, want start_date/working_days/return to match input", r.Fields)
+	}
+}
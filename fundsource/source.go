@@ -0,0 +1,26 @@
+package fundsource
+
+import (
+	"context"
+	"time"
+
+	"github.com/withoutaname/fund/httpx"
+)
+
+// Record is one data point collected by a Source, shaped so a sink can
+// write it without knowing anything about the eastmoney endpoint it came
+// from: a timestamp, the tags that identify the series, and its fields.
+type Record struct {
+	Time   time.Time
+	Tags   map[string]string
+	Fields map[string]interface{}
+}
+
+// Source collects one kind of per-fund data beyond historical NAV (lsjz),
+// e.g. holdings, dividends, manager info or the intraday NAV estimate.
+// Measurement names its own InfluxDB measurement so sinks can keep each
+// source's records separate from the core "fund" NAV series.
+type Source interface {
+	Measurement() string
+	Collect(ctx context.Context, client *httpx.Client, node FundNode) ([]Record, error)
+}
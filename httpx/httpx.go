@@ -0,0 +1,172 @@
+// Package httpx provides a resilient HTTP client for scraping eastmoney,
+// with context support, exponential backoff and per-host rate limiting.
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/withoutaname/fund/metrics"
+)
+
+// Config controls retry and rate-limiting behaviour of a Client.
+type Config struct {
+	// MaxRetries is the number of retries made after the first attempt
+	// before giving up, so a request is tried at most MaxRetries+1 times.
+	MaxRetries int
+	// Timeout bounds a single request attempt, including redirects.
+	Timeout time.Duration
+	// RatePerSecond is the steady-state request rate allowed per host.
+	RatePerSecond float64
+	// RateBurst is the burst size allowed per host on top of RatePerSecond.
+	RateBurst int
+	// BaseBackoff is the initial delay between retries, doubled each attempt
+	// and jittered, unless the server specifies Retry-After.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the computed backoff delay.
+	MaxBackoff time.Duration
+}
+
+// DefaultConfig is tuned to stay well under eastmoney's rate limits.
+var DefaultConfig = Config{
+	MaxRetries:    3,
+	Timeout:       10 * time.Second,
+	RatePerSecond: 2,
+	RateBurst:     4,
+	BaseBackoff:   500 * time.Millisecond,
+	MaxBackoff:    30 * time.Second,
+}
+
+// Client is an http.Client wrapper with retries, backoff and per-host
+// rate limiting. The zero value is not usable; use New.
+type Client struct {
+	cfg     Config
+	http    *http.Client
+	headers http.Header
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// New builds a Client using cfg and the given default request headers
+// (e.g. Referer and User-Agent).
+func New(cfg Config, headers http.Header) *Client {
+	return &Client{
+		cfg:     cfg,
+		headers: headers,
+		http: &http.Client{
+			Timeout: cfg.Timeout,
+			Transport: &http.Transport{
+				MaxIdleConns:        100,
+				MaxIdleConnsPerHost: 10,
+				IdleConnTimeout:     90 * time.Second,
+				DialContext: (&net.Dialer{
+					Timeout:   5 * time.Second,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+			},
+		},
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+func (c *Client) limiterFor(host string) *rate.Limiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.limiters[host]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(c.cfg.RatePerSecond), c.cfg.RateBurst)
+		c.limiters[host] = l
+	}
+	return l
+}
+
+// Get fetches u, honoring ctx cancellation, retrying with exponential
+// backoff and respecting per-host rate limits and Retry-After.
+func (c *Client) Get(ctx context.Context, u string) (*http.Response, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+	limiter := c.limiterFor(parsed.Host)
+
+	var lastErr error
+	var retryAfter time.Duration
+	backoff := c.cfg.BaseBackoff
+	for attempt := 0; attempt <= c.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			metrics.RetriesTotal.WithLabelValues(parsed.Host).Inc()
+			if err := c.wait(ctx, backoff, retryAfter); err != nil {
+				return nil, err
+			}
+			backoff *= 2
+			if backoff > c.cfg.MaxBackoff {
+				backoff = c.cfg.MaxBackoff
+			}
+		}
+		retryAfter = 0
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header = c.headers.Clone()
+
+		metrics.RequestsTotal.WithLabelValues(parsed.Host).Inc()
+		resp, err := c.http.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("http get error, err=[%v], url=[%s]", err, u)
+			continue
+		}
+		metrics.ResponseStatusTotal.WithLabelValues(parsed.Host, strconv.Itoa(resp.StatusCode)).Inc()
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		lastErr = fmt.Errorf("http code error, code=[%d], url=[%s]", resp.StatusCode, u)
+		resp.Body.Close()
+	}
+	return nil, lastErr
+}
+
+// wait sleeps for the jittered backoff d, or for exactly retryAfter when
+// the server specified one.
+func (c *Client) wait(ctx context.Context, d, retryAfter time.Duration) error {
+	delay := d/2 + time.Duration(rand.Int63n(int64(d)/2+1))
+	if retryAfter > 0 {
+		delay = retryAfter
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
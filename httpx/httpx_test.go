@@ -0,0 +1,111 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	future := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+
+	cases := []struct {
+		name    string
+		in      string
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{"empty", "", 0, 0},
+		{"seconds", "5", 5 * time.Second, 5 * time.Second},
+		{"invalid", "not-a-duration-or-date", 0, 0},
+		{"http-date", future, 29 * time.Second, 30 * time.Second},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := parseRetryAfter(c.in)
+			if got < c.wantMin || got > c.wantMax {
+				t.Errorf("parseRetryAfter(%q) = %v, want between %v and %v", c.in, got, c.wantMin, c.wantMax)
+			}
+		})
+	}
+}
+
+func TestClientGetRetriesOnServerErrorThenSucceeds(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig
+	cfg.MaxRetries = 3
+	cfg.BaseBackoff = time.Millisecond
+	cfg.MaxBackoff = 5 * time.Millisecond
+	cfg.RatePerSecond = 1000
+	cfg.RateBurst = 1000
+
+	c := New(cfg, http.Header{"User-Agent": []string{"test"}})
+	resp, err := c.Get(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestClientGetGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig
+	cfg.MaxRetries = 2
+	cfg.BaseBackoff = time.Millisecond
+	cfg.MaxBackoff = 5 * time.Millisecond
+	cfg.RatePerSecond = 1000
+	cfg.RateBurst = 1000
+
+	c := New(cfg, http.Header{})
+	_, err := c.Get(context.Background(), srv.URL)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if got, want := atomic.LoadInt32(&attempts), int32(cfg.MaxRetries+1); got != want {
+		t.Errorf("attempts = %d, want %d", got, want)
+	}
+}
+
+func TestClientGetRespectsContextCancellation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig
+	cfg.MaxRetries = 10
+	cfg.BaseBackoff = 50 * time.Millisecond
+	cfg.MaxBackoff = time.Second
+	cfg.RatePerSecond = 1000
+	cfg.RateBurst = 1000
+
+	c := New(cfg, http.Header{})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Get(ctx, srv.URL)
+	if err == nil {
+		t.Fatal("expected an error from a canceled context, got nil")
+	}
+}
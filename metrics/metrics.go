@@ -0,0 +1,58 @@
+// Package metrics holds the Prometheus collectors shared across the
+// crawler's packages, so http status, retries and sink latency from deep
+// inside the call stack surface on the admin server's /metrics endpoint.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// RequestsTotal counts every outbound HTTP request attempt, labeled by host.
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fund_crawler_requests_total",
+		Help: "Total outbound HTTP requests made to a given host.",
+	}, []string{"host"})
+
+	// RetriesTotal counts retried requests, labeled by host.
+	RetriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fund_crawler_retries_total",
+		Help: "Total outbound HTTP request retries, by host.",
+	}, []string{"host"})
+
+	// ResponseStatusTotal counts responses by host and HTTP status code.
+	ResponseStatusTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fund_crawler_response_status_total",
+		Help: "Total outbound HTTP responses, by host and status code.",
+	}, []string{"host", "code"})
+
+	// FundsScraped counts successfully crawled funds.
+	FundsScraped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "fund_crawler_funds_scraped_total",
+		Help: "Total funds successfully crawled and written to the sink.",
+	})
+
+	// SinkWriteDuration measures sink write latency, labeled by sink name.
+	SinkWriteDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fund_crawler_sink_write_duration_seconds",
+		Help:    "Latency of sink writes, by sink name.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"sink"})
+
+	// QueueDepth reports the number of funds queued for a worker to pick up.
+	QueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "fund_crawler_queue_depth",
+		Help: "Number of funds currently queued for crawling.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(RequestsTotal, RetriesTotal, ResponseStatusTotal, FundsScraped, SinkWriteDuration, QueueDepth)
+}
+
+// ObserveSinkWrite records how long a write to the named sink took.
+func ObserveSinkWrite(name string, since time.Time) {
+	SinkWriteDuration.WithLabelValues(name).Observe(time.Since(since).Seconds())
+}
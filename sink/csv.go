@@ -0,0 +1,65 @@
+package sink
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/withoutaname/fund/fundsource"
+)
+
+// CSVConfig configures a CSVSink.
+type CSVConfig struct {
+	// Dir is the directory one CSV file per fund code is written into.
+	Dir string
+}
+
+// CSVSink appends NAV records to a per-fund CSV file under Dir.
+//
+// CSVSink does not implement LatestDateProvider, so the crawler's
+// incremental fetch always starts from the beginning of each fund's
+// history and Write re-appends it in full on every pass. Left running
+// against a csv sink, the output files grow without bound and contain
+// duplicate rows; use the influxdb sink if that matters.
+type CSVSink struct {
+	dir string
+}
+
+// NewCSVSink creates a CSVSink rooted at cfg.Dir, creating it if necessary.
+func NewCSVSink(cfg CSVConfig) (*CSVSink, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &CSVSink{dir: cfg.Dir}, nil
+}
+
+func (s *CSVSink) Write(node fundsource.FundNode, points []fundsource.FundDetail) error {
+	if len(points) == 0 {
+		return nil
+	}
+	path := filepath.Join(s.dir, fmt.Sprintf("%s.csv", node.Code))
+	exists := true
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		exists = false
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if !exists {
+		if err := w.Write([]string{"FSRQ", "DWJZ", "LJJZ", "JZZZL", "NAVTYPE", "SGZT", "SHZT"}); err != nil {
+			return err
+		}
+	}
+	for _, p := range points {
+		if err := w.Write([]string{p.FSRQ, p.DWJZ, p.LJJZ, p.JZZZL, p.NAVTYPE, p.SGZT, p.SHZT}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
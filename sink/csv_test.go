@@ -0,0 +1,75 @@
+package sink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/withoutaname/fund/fundsource"
+)
+
+func TestCSVSinkWriteAppendsWithoutDuplicatingHeader(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewCSVSink(CSVConfig{Dir: dir})
+	if err != nil {
+		t.Fatalf("NewCSVSink: %v", err)
+	}
+	node := fundsource.FundNode{Code: "000001"}
+
+	first := []fundsource.FundDetail{{FSRQ: "2024-01-01", DWJZ: "1.0", LJJZ: "1.0"}}
+	if err := s.Write(node, first); err != nil {
+		t.Fatalf("first Write: %v", err)
+	}
+	second := []fundsource.FundDetail{{FSRQ: "2024-01-02", DWJZ: "1.1", LJJZ: "1.1"}}
+	if err := s.Write(node, second); err != nil {
+		t.Fatalf("second Write: %v", err)
+	}
+
+	bs, err := os.ReadFile(filepath.Join(dir, "000001.csv"))
+	if err != nil {
+		t.Fatalf("reading output file: %v", err)
+	}
+	lines := splitLines(string(bs))
+	want := []string{
+		"FSRQ,DWJZ,LJJZ,JZZZL,NAVTYPE,SGZT,SHZT",
+		"2024-01-01,1.0,1.0,,,,",
+		"2024-01-02,1.1,1.1,,,,",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines %q, want %d lines %q", len(lines), lines, len(want), want)
+	}
+	for i, line := range want {
+		if lines[i] != line {
+			t.Errorf("line %d: got %q, want %q", i, lines[i], line)
+		}
+	}
+}
+
+func TestCSVSinkWriteNoPointsIsNoop(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewCSVSink(CSVConfig{Dir: dir})
+	if err != nil {
+		t.Fatalf("NewCSVSink: %v", err)
+	}
+	if err := s.Write(fundsource.FundNode{Code: "000001"}, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "000001.csv")); !os.IsNotExist(err) {
+		t.Fatalf("expected no file to be created for zero points, stat err = %v", err)
+	}
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		lines = append(lines, s[start:])
+	}
+	return lines
+}
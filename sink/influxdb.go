@@ -0,0 +1,81 @@
+package sink
+
+import (
+	"strconv"
+
+	"github.com/araddon/dateparse"
+	"github.com/influxdata/influxdb/client/v2"
+	"go.uber.org/zap"
+
+	"github.com/withoutaname/fund/fundsource"
+)
+
+// InfluxDBConfig configures an InfluxDBSink.
+type InfluxDBConfig struct {
+	Addr     string
+	Database string
+}
+
+// InfluxDBSink writes fund NAV points to an InfluxDB database.
+type InfluxDBSink struct {
+	client   client.Client
+	database string
+	logger   *zap.Logger
+}
+
+// NewInfluxDBSink connects to the InfluxDB instance described by cfg.
+func NewInfluxDBSink(cfg InfluxDBConfig, logger *zap.Logger) (*InfluxDBSink, error) {
+	c, err := client.NewHTTPClient(client.HTTPConfig{
+		Addr: cfg.Addr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &InfluxDBSink{client: c, database: cfg.Database, logger: logger}, nil
+}
+
+func (s *InfluxDBSink) Write(node fundsource.FundNode, points []fundsource.FundDetail) error {
+	bp, err := client.NewBatchPoints(client.BatchPointsConfig{
+		Database: s.database,
+	})
+	if err != nil {
+		return err
+	}
+	tags := map[string]string{
+		"code":    node.Code,
+		"abridge": node.Abridge,
+		"name":    node.Name,
+		"type":    node.Type,
+		"pinyin":  node.Pinyin,
+	}
+	for _, fund := range points {
+		ptime, err := dateparse.ParseLocal(fund.FSRQ)
+		if err != nil {
+			return err
+		}
+		fields := map[string]interface{}{
+			"NAVTYPE": fund.NAVTYPE,
+			"SGZT":    fund.SGZT,
+			"SHZT":    fund.SHZT,
+		}
+		if DWJZ, err := strconv.ParseFloat(fund.DWJZ, 64); err == nil {
+			fields["DWJZ"] = DWJZ
+		}
+		if LJJZ, err := strconv.ParseFloat(fund.LJJZ, 64); err == nil {
+			fields["LJJZ"] = LJJZ
+		}
+		if JZZZL, err := strconv.ParseFloat(fund.JZZZL, 64); err == nil {
+			fields["JZZZL"] = JZZZL
+		}
+		pt, err := client.NewPoint("fund", tags, fields, ptime)
+		if err != nil {
+			return err
+		}
+		bp.AddPoint(pt)
+	}
+	if err := s.client.Write(bp); err != nil {
+		return err
+	}
+	s.logger.Debug("sink successfully", zap.String("code", node.Code), zap.String("name", node.Name), zap.Int("count", len(bp.Points())))
+	return nil
+}
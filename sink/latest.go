@@ -0,0 +1,37 @@
+package sink
+
+import "github.com/influxdata/influxdb/client/v2"
+
+// LatestDateProvider is implemented by sinks that can report the most
+// recent FSRQ already stored for a fund code, so crawls can resume
+// incrementally instead of re-fetching history that is already known.
+type LatestDateProvider interface {
+	LatestDate(code string) (string, error)
+}
+
+// LatestDate returns the timestamp of the most recent point stored for
+// code, as the RFC3339 string InfluxDB reports (not the bare yyyy-MM-dd
+// FSRQ form), or the empty string if the fund has no data yet.
+func (s *InfluxDBSink) LatestDate(code string) (string, error) {
+	q := client.NewQueryWithParameters(
+		`SELECT last("DWJZ") FROM "fund" WHERE "code" = $code`,
+		s.database, "",
+		map[string]interface{}{"code": code},
+	)
+	resp, err := s.client.Query(q)
+	if err != nil {
+		return "", err
+	}
+	if resp.Error() != nil {
+		return "", resp.Error()
+	}
+	if len(resp.Results) == 0 || len(resp.Results[0].Series) == 0 || len(resp.Results[0].Series[0].Values) == 0 {
+		return "", nil
+	}
+	row := resp.Results[0].Series[0].Values[0]
+	ts, ok := row[0].(string)
+	if !ok {
+		return "", nil
+	}
+	return ts, nil
+}
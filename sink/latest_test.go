@@ -0,0 +1,45 @@
+package sink
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/client/v2"
+	"github.com/influxdata/influxdb/models"
+)
+
+func TestLatestDateBindsCodeParameter(t *testing.T) {
+	fc := &fakeQueryClient{
+		resp: &client.Response{
+			Results: []client.Result{{
+				Series: []models.Row{{
+					Values: [][]interface{}{{"2024-03-01T00:00:00Z", 1.23}},
+				}},
+			}},
+		},
+	}
+	s := &InfluxDBSink{client: fc, database: "fund"}
+
+	got, err := s.LatestDate("000001")
+	if err != nil {
+		t.Fatalf("LatestDate: %v", err)
+	}
+	if got != "2024-03-01T00:00:00Z" {
+		t.Fatalf("got %q, want 2024-03-01T00:00:00Z", got)
+	}
+	if fc.lastQuery.Parameters["code"] != "000001" {
+		t.Fatalf("code not bound as a query parameter: %+v", fc.lastQuery.Parameters)
+	}
+}
+
+func TestLatestDateEmptyWhenNoData(t *testing.T) {
+	fc := &fakeQueryClient{resp: &client.Response{Results: []client.Result{{}}}}
+	s := &InfluxDBSink{client: fc, database: "fund"}
+
+	got, err := s.LatestDate("000001")
+	if err != nil {
+		t.Fatalf("LatestDate: %v", err)
+	}
+	if got != "" {
+		t.Fatalf("got %q, want empty string for a fund with no data", got)
+	}
+}
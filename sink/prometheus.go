@@ -0,0 +1,55 @@
+package sink
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"github.com/withoutaname/fund/fundsource"
+)
+
+// PrometheusConfig configures a PrometheusSink.
+type PrometheusConfig struct {
+	// PushGatewayAddr is the base URL of the pushgateway, e.g. http://localhost:9091.
+	PushGatewayAddr string
+	Job             string
+}
+
+// PrometheusSink pushes the latest NAV for each fund to a Prometheus pushgateway.
+type PrometheusSink struct {
+	cfg      PrometheusConfig
+	navGauge *prometheus.GaugeVec
+	accGauge *prometheus.GaugeVec
+}
+
+// NewPrometheusSink builds a PrometheusSink that pushes to cfg.PushGatewayAddr.
+func NewPrometheusSink(cfg PrometheusConfig) *PrometheusSink {
+	return &PrometheusSink{
+		cfg: cfg,
+		navGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fund_unit_nav",
+			Help: "Latest unit NAV (DWJZ) of a fund.",
+		}, []string{"code", "name"}),
+		accGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fund_accumulated_nav",
+			Help: "Latest accumulated NAV (LJJZ) of a fund.",
+		}, []string{"code", "name"}),
+	}
+}
+
+func (s *PrometheusSink) Write(node fundsource.FundNode, points []fundsource.FundDetail) error {
+	if len(points) == 0 {
+		return nil
+	}
+	latest := points[len(points)-1]
+	if dwjz, err := parseFloatOrZero(latest.DWJZ); err == nil {
+		s.navGauge.WithLabelValues(node.Code, node.Name).Set(dwjz)
+	}
+	if ljjz, err := parseFloatOrZero(latest.LJJZ); err == nil {
+		s.accGauge.WithLabelValues(node.Code, node.Name).Set(ljjz)
+	}
+	return push.New(s.cfg.PushGatewayAddr, s.cfg.Job).
+		Collector(s.navGauge).
+		Collector(s.accGauge).
+		Grouping("code", node.Code).
+		Push()
+}
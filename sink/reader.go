@@ -0,0 +1,117 @@
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb/client/v2"
+
+	"github.com/withoutaname/fund/fundsource"
+)
+
+// valueToString renders an InfluxQL result cell (a string, a json.Number,
+// or a time.Time-formatted string) as a string for use in FundDetail.
+func valueToString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// Reader is implemented by sinks that can serve fund data back out, so the
+// admin API can answer queries without speaking InfluxQL directly.
+type Reader interface {
+	ListFunds() ([]fundsource.FundNode, error)
+	NAV(code, from, to string) ([]fundsource.FundDetail, error)
+}
+
+// ListFunds returns every distinct fund code with data in the database.
+func (s *InfluxDBSink) ListFunds() ([]fundsource.FundNode, error) {
+	q := client.NewQuery(`SHOW TAG VALUES FROM "fund" WITH KEY = "code"`, s.database, "")
+	resp, err := s.client.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+	var nodes []fundsource.FundNode
+	if len(resp.Results) == 0 {
+		return nodes, nil
+	}
+	for _, series := range resp.Results[0].Series {
+		for _, v := range series.Values {
+			if len(v) < 2 {
+				continue
+			}
+			code, ok := v[1].(string)
+			if !ok {
+				continue
+			}
+			nodes = append(nodes, fundsource.FundNode{Code: code})
+		}
+	}
+	return nodes, nil
+}
+
+// NAV returns the NAV history for code between from and to (RFC3339
+// timestamps; either may be empty for an open bound).
+func (s *InfluxDBSink) NAV(code, from, to string) ([]fundsource.FundDetail, error) {
+	query := `SELECT "DWJZ", "LJJZ", "JZZZL" FROM "fund" WHERE "code" = $code`
+	params := map[string]interface{}{"code": code}
+	if from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from timestamp: %v", err)
+		}
+		query += ` AND time >= $from`
+		params["from"] = t.Format(time.RFC3339Nano)
+	}
+	if to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to timestamp: %v", err)
+		}
+		query += ` AND time <= $to`
+		params["to"] = t.Format(time.RFC3339Nano)
+	}
+	q := client.NewQueryWithParameters(query, s.database, "", params)
+	resp, err := s.client.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+	var details []fundsource.FundDetail
+	if len(resp.Results) == 0 || len(resp.Results[0].Series) == 0 {
+		return details, nil
+	}
+	series := resp.Results[0].Series[0]
+	for _, row := range series.Values {
+		detail := fundsource.FundDetail{}
+		for i, col := range series.Columns {
+			if row[i] == nil {
+				continue
+			}
+			val := valueToString(row[i])
+			switch col {
+			case "time":
+				detail.FSRQ = val
+			case "DWJZ":
+				detail.DWJZ = val
+			case "LJJZ":
+				detail.LJJZ = val
+			case "JZZZL":
+				detail.JZZZL = val
+			}
+		}
+		details = append(details, detail)
+	}
+	return details, nil
+}
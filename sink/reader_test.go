@@ -0,0 +1,83 @@
+package sink
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/client/v2"
+	"github.com/influxdata/influxdb/models"
+)
+
+type fakeQueryClient struct {
+	client.Client
+	resp       *client.Response
+	lastQuery  client.Query
+	queryCount int
+}
+
+func (f *fakeQueryClient) Query(q client.Query) (*client.Response, error) {
+	f.lastQuery = q
+	f.queryCount++
+	return f.resp, nil
+}
+
+func TestListFunds(t *testing.T) {
+	fc := &fakeQueryClient{
+		resp: &client.Response{
+			Results: []client.Result{{
+				Series: []models.Row{{
+					Columns: []string{"key", "value"},
+					Values: [][]interface{}{
+						{"code", "000001"},
+						{"code", "000002"},
+					},
+				}},
+			}},
+		},
+	}
+	s := &InfluxDBSink{client: fc, database: "fund"}
+
+	nodes, err := s.ListFunds()
+	if err != nil {
+		t.Fatalf("ListFunds: %v", err)
+	}
+	if len(nodes) != 2 || nodes[0].Code != "000001" || nodes[1].Code != "000002" {
+		t.Fatalf("got %+v, want codes 000001, 000002", nodes)
+	}
+}
+
+func TestNAVBindsParametersAndParsesRows(t *testing.T) {
+	fc := &fakeQueryClient{
+		resp: &client.Response{
+			Results: []client.Result{{
+				Series: []models.Row{{
+					Columns: []string{"time", "DWJZ", "LJJZ", "JZZZL"},
+					Values: [][]interface{}{
+						{"2024-01-01T00:00:00Z", 1.23, 1.5, 0.1},
+					},
+				}},
+			}},
+		},
+	}
+	s := &InfluxDBSink{client: fc, database: "fund"}
+
+	details, err := s.NAV("000001", "2024-01-01T00:00:00Z", "2024-02-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("NAV: %v", err)
+	}
+	if len(details) != 1 || details[0].FSRQ != "2024-01-01T00:00:00Z" {
+		t.Fatalf("got %+v, want a single row for 2024-01-01", details)
+	}
+	if fc.lastQuery.Parameters["code"] != "000001" {
+		t.Fatalf("code not bound as a query parameter: %+v", fc.lastQuery.Parameters)
+	}
+	if _, ok := fc.lastQuery.Parameters["from"]; !ok {
+		t.Fatalf("from not bound as a query parameter: %+v", fc.lastQuery.Parameters)
+	}
+}
+
+func TestNAVRejectsInvalidTimestamp(t *testing.T) {
+	s := &InfluxDBSink{client: &fakeQueryClient{}, database: "fund"}
+	if _, err := s.NAV("000001", "not-a-timestamp", ""); err == nil {
+		t.Fatal("expected an error for an invalid from timestamp")
+	}
+}
@@ -0,0 +1,37 @@
+package sink
+
+import (
+	"github.com/influxdata/influxdb/client/v2"
+
+	"github.com/withoutaname/fund/fundsource"
+)
+
+// RecordWriter is implemented by sinks that can persist the generic
+// measurement/tag/field records produced by a fundsource.Source, as
+// opposed to the fixed NAV shape Sink.Write expects. Sinks with a rigid
+// per-fund schema (csv, parquet) do not implement it.
+type RecordWriter interface {
+	WriteRecords(measurement string, records []fundsource.Record) error
+}
+
+func (s *InfluxDBSink) WriteRecords(measurement string, records []fundsource.Record) error {
+	bp, err := client.NewBatchPoints(client.BatchPointsConfig{
+		Database: s.database,
+	})
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if len(r.Fields) == 0 {
+			// InfluxDB rejects a point with no fields outright; skip it
+			// rather than letting it fail the whole batch.
+			continue
+		}
+		pt, err := client.NewPoint(measurement, r.Tags, r.Fields, r.Time)
+		if err != nil {
+			return err
+		}
+		bp.AddPoint(pt)
+	}
+	return s.client.Write(bp)
+}
@@ -0,0 +1,53 @@
+package sink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/client/v2"
+
+	"github.com/withoutaname/fund/fundsource"
+)
+
+type fakeClient struct {
+	client.Client
+	written client.BatchPoints
+}
+
+func (f *fakeClient) Write(bp client.BatchPoints) error {
+	f.written = bp
+	return nil
+}
+
+func TestWriteRecordsSkipsEmptyFields(t *testing.T) {
+	fc := &fakeClient{}
+	s := &InfluxDBSink{client: fc, database: "fund"}
+
+	records := []fundsource.Record{
+		{
+			Time:   time.Now(),
+			Tags:   map[string]string{"code": "000001"},
+			Fields: map[string]interface{}{"per_share": 0.1},
+		},
+		{
+			Time:   time.Now(),
+			Tags:   map[string]string{"code": "000002"},
+			Fields: map[string]interface{}{},
+		},
+		{
+			Time:   time.Now(),
+			Tags:   map[string]string{"code": "000003"},
+			Fields: map[string]interface{}{"per_share": 0.2},
+		},
+	}
+
+	if err := s.WriteRecords("fund_dividend", records); err != nil {
+		t.Fatalf("WriteRecords returned error: %v", err)
+	}
+	if fc.written == nil {
+		t.Fatal("client.Write was never called")
+	}
+	if got, want := len(fc.written.Points()), 2; got != want {
+		t.Fatalf("got %d points, want %d (empty-Fields record should have been skipped)", got, want)
+	}
+}
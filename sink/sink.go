@@ -0,0 +1,9 @@
+// Package sink writes crawled fund data to a configurable destination.
+package sink
+
+import "github.com/withoutaname/fund/fundsource"
+
+// Sink persists a fund's NAV history to some storage backend.
+type Sink interface {
+	Write(node fundsource.FundNode, points []fundsource.FundDetail) error
+}
@@ -0,0 +1,10 @@
+package sink
+
+import "strconv"
+
+func parseFloatOrZero(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
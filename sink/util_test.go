@@ -0,0 +1,27 @@
+package sink
+
+import "testing"
+
+func TestParseFloatOrZero(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{name: "empty", in: "", want: 0},
+		{name: "valid", in: "1.23", want: 1.23},
+		{name: "invalid", in: "n/a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFloatOrZero(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFloatOrZero(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Fatalf("parseFloatOrZero(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}